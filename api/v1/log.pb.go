@@ -0,0 +1,114 @@
+// Package log_v1 hand-implements the wire types described by
+// api/v1/log.proto against the legacy github.com/golang/protobuf API.
+// It is NOT protoc-gen-go output (no raw file descriptors, no
+// Marshal/Unmarshal on the messages) - there's no protoc invocation
+// anywhere in this tree. Keep it in sync with log.proto by hand, and if
+// this ever gets wired up to real codegen, replace this file wholesale
+// rather than patching it.
+package log_v1
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+
+// Record is the unit of data produced to and consumed from the log.
+type Record struct {
+	Value  []byte `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Offset uint64 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+}
+
+func (m *Record) Reset()         { *m = Record{} }
+func (m *Record) String() string { return proto.CompactTextString(m) }
+func (*Record) ProtoMessage()    {}
+
+func (m *Record) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *Record) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+// ProduceRequest is the argument to the Log service's Produce RPC.
+type ProduceRequest struct {
+	Record *Record `protobuf:"bytes,1,opt,name=record,proto3" json:"record,omitempty"`
+}
+
+func (m *ProduceRequest) Reset()         { *m = ProduceRequest{} }
+func (m *ProduceRequest) String() string { return proto.CompactTextString(m) }
+func (*ProduceRequest) ProtoMessage()    {}
+
+func (m *ProduceRequest) GetRecord() *Record {
+	if m != nil {
+		return m.Record
+	}
+	return nil
+}
+
+// ProduceResponse tells the caller the offset the log stored the record
+// under.
+type ProduceResponse struct {
+	Offset uint64 `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+}
+
+func (m *ProduceResponse) Reset()         { *m = ProduceResponse{} }
+func (m *ProduceResponse) String() string { return proto.CompactTextString(m) }
+func (*ProduceResponse) ProtoMessage()    {}
+
+func (m *ProduceResponse) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+// ConsumeRequest is the argument to the Log service's Consume RPC.
+type ConsumeRequest struct {
+	Offset uint64 `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+}
+
+func (m *ConsumeRequest) Reset()         { *m = ConsumeRequest{} }
+func (m *ConsumeRequest) String() string { return proto.CompactTextString(m) }
+func (*ConsumeRequest) ProtoMessage()    {}
+
+func (m *ConsumeRequest) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+// ConsumeResponse carries the record the caller asked for.
+type ConsumeResponse struct {
+	Record *Record `protobuf:"bytes,1,opt,name=record,proto3" json:"record,omitempty"`
+}
+
+func (m *ConsumeResponse) Reset()         { *m = ConsumeResponse{} }
+func (m *ConsumeResponse) String() string { return proto.CompactTextString(m) }
+func (*ConsumeResponse) ProtoMessage()    {}
+
+func (m *ConsumeResponse) GetRecord() *Record {
+	if m != nil {
+		return m.Record
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Record)(nil), "log.v1.Record")
+	proto.RegisterType((*ProduceRequest)(nil), "log.v1.ProduceRequest")
+	proto.RegisterType((*ProduceResponse)(nil), "log.v1.ProduceResponse")
+	proto.RegisterType((*ConsumeRequest)(nil), "log.v1.ConsumeRequest")
+	proto.RegisterType((*ConsumeResponse)(nil), "log.v1.ConsumeResponse")
+}
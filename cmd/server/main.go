@@ -1,12 +1,78 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"google.golang.org/grpc"
+
+	internallog "github.com/drakelthedragon/proglog/internal/log"
 	"github.com/drakelthedragon/proglog/internal/server"
 )
 
 func main() {
-	srv := server.NewHTTPServer(":4000")
-	log.Fatal(srv.ListenAndServe())
+	shutdownTimeout := flag.Duration(
+		"shutdown-timeout",
+		5*time.Second,
+		"how long to wait for in-flight RPCs to drain on shutdown before forcing connections closed",
+	)
+	flag.Parse()
+
+	commitLog, err := internallog.NewLog("data", internallog.Config{})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	srv, err := server.NewGRPCServer(&server.Config{CommitLog: commitLog})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ln, err := net.Listen("tcp", ":8400")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- srv.Serve(ln)
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	select {
+	case err := <-errc:
+		log.Fatal(err)
+	case <-ctx.Done():
+		drain(srv, *shutdownTimeout)
+	}
+
+	if err := commitLog.Close(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// drain waits for srv's in-flight RPCs to finish and its connections to
+// close via GracefulStop, but falls back to an immediate Stop once
+// timeout elapses so one stuck tailing client (see ConsumeStream) can't
+// hang shutdown forever.
+func drain(srv *grpc.Server, timeout time.Duration) {
+	done := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		srv.Stop()
+	}
 }
@@ -0,0 +1,34 @@
+package log
+
+import (
+	"testing"
+
+	api "github.com/drakelthedragon/proglog/api/v1"
+)
+
+func TestCodecsRoundTrip(t *testing.T) {
+	want := Record{Value: []byte("hello world"), Offset: 7}
+
+	for name, codec := range map[string]Codec{
+		"json":     JSONCodec{},
+		"protobuf": ProtobufCodec{},
+	} {
+		p, err := codec.Marshal(want.toWire())
+		assertNoError(t, err)
+
+		pb := &api.Record{}
+		assertNoError(t, codec.Unmarshal(p, pb))
+
+		got := recordFromWire(pb)
+		if string(got.Value) != string(want.Value) {
+			t.Errorf("%s: got value %q, want %q", name, got.Value, want.Value)
+		}
+		assertEqual(t, got.Offset, want.Offset)
+	}
+}
+
+func TestProtobufCodecRejectsNonProtoValues(t *testing.T) {
+	if _, err := (ProtobufCodec{}).Marshal("not a proto.Message"); err == nil {
+		t.Fatal("expected an error marshaling a non-proto.Message value")
+	}
+}
@@ -0,0 +1,63 @@
+package log
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestIndexEntryWidth(t *testing.T) {
+	assertEqual(t, offWidth, uint64(4))
+	assertEqual(t, posWidth, uint64(8))
+	assertEqual(t, uint64(entWidth), uint64(12))
+}
+
+func TestIndex(t *testing.T) {
+	f, err := ioutil.TempFile("", "index_test")
+	assertNoError(t, err)
+	defer os.Remove(f.Name())
+
+	idx, err := newIndex(f, 1024)
+	assertNoError(t, err)
+
+	_, _, err = idx.Read(-1)
+	if err != io.EOF {
+		t.Fatalf("got %v, want io.EOF", err)
+	}
+
+	entries := []struct {
+		Off uint32
+		Pos uint64
+	}{
+		{Off: 0, Pos: 0},
+		{Off: 1, Pos: 10},
+	}
+
+	for _, want := range entries {
+		assertNoError(t, idx.Write(want.Off, want.Pos))
+
+		_, pos, err := idx.Read(int64(want.Off))
+		assertNoError(t, err)
+		assertEqual(t, pos, want.Pos)
+	}
+
+	// The index and its mmap are a fixed size, so reading past the
+	// entries that have actually been written returns io.EOF.
+	_, _, err = idx.Read(int64(len(entries)))
+	if err != io.EOF {
+		t.Fatalf("got %v, want io.EOF", err)
+	}
+
+	assertNoError(t, idx.Close())
+
+	// Reopening the index should pick up where the previous one left off.
+	f, _ = os.OpenFile(f.Name(), os.O_RDWR, 0644)
+	idx, err = newIndex(f, 1024)
+	assertNoError(t, err)
+
+	off, pos, err := idx.Read(-1)
+	assertNoError(t, err)
+	assertEqual(t, off, entries[1].Off)
+	assertEqual(t, pos, entries[1].Pos)
+}
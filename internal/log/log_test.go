@@ -0,0 +1,124 @@
+package log
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestLog(t *testing.T) {
+	dir, err := ioutil.TempDir("", "log_test")
+	assertNoError(t, err)
+	defer os.RemoveAll(dir)
+
+	c := Config{}
+	c.Segment.MaxStoreBytes = 32
+
+	l, err := NewLog(dir, c)
+	assertNoError(t, err)
+
+	record := &Record{Value: []byte("hello world")}
+
+	off, err := l.Append(context.Background(), *record)
+	assertNoError(t, err)
+	assertEqual(t, off, uint64(0))
+
+	got, err := l.Read(context.Background(), off)
+	assertNoError(t, err)
+	assertEqual(t, string(got.Value), string(record.Value))
+
+	_, err = l.Read(context.Background(), 1)
+	if err != ErrOffsetNotFound {
+		t.Fatalf("got %v, want ErrOffsetNotFound", err)
+	}
+
+	// Appending enough records to span segments exercises both the
+	// rollover and the multi-segment Reader/Truncate paths.
+	for i := 0; i < 3; i++ {
+		_, err := l.Append(context.Background(), *record)
+		assertNoError(t, err)
+	}
+	if len(l.segments) < 2 {
+		t.Fatalf("expected more than one segment, got %d", len(l.segments))
+	}
+
+	lowest, err := l.LowestOffset()
+	assertNoError(t, err)
+	assertEqual(t, lowest, uint64(0))
+
+	highest, err := l.HighestOffset()
+	assertNoError(t, err)
+	assertEqual(t, highest, uint64(3))
+
+	b, err := ioutil.ReadAll(l.Reader())
+	assertNoError(t, err)
+	if len(b) == 0 {
+		t.Fatal("expected the reader to return the store's contents")
+	}
+	// The reader must skip each segment's store header, or the first
+	// lenWidth bytes would be header bytes instead of a record length.
+	if recLen := enc.Uint64(b[:lenWidth]); recLen > uint64(len(b)) {
+		t.Fatalf("got implausible record length %d from the first frame, want a store header to be skipped", recLen)
+	}
+
+	assertNoError(t, l.Truncate(1))
+	_, err = l.Read(context.Background(), 1)
+	if err != ErrOffsetNotFound {
+		t.Fatalf("got %v, want ErrOffsetNotFound for truncated offset", err)
+	}
+
+	assertNoError(t, l.Close())
+
+	// A log reopened on the same directory should rehydrate its segments
+	// from the files on disk.
+	n, err := NewLog(dir, c)
+	assertNoError(t, err)
+	highest, err = n.HighestOffset()
+	assertNoError(t, err)
+	assertEqual(t, highest, uint64(3))
+
+	assertNoError(t, n.Remove())
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Fatal("log directory should have been removed")
+	}
+}
+
+// TestLogTruncateAllSegments guards against Truncate leaving
+// activeSegment pointing at a removed segment when it empties
+// l.segments entirely, which panicked HighestOffset/LowestOffset and
+// would have let Append silently write through a closed, deleted-file
+// segment.
+func TestLogTruncateAllSegments(t *testing.T) {
+	dir, err := ioutil.TempDir("", "log_truncate_all_test")
+	assertNoError(t, err)
+	defer os.RemoveAll(dir)
+
+	c := Config{}
+	c.Segment.MaxStoreBytes = 32
+
+	l, err := NewLog(dir, c)
+	assertNoError(t, err)
+
+	record := &Record{Value: []byte("hello world")}
+	for i := 0; i < 3; i++ {
+		_, err := l.Append(context.Background(), *record)
+		assertNoError(t, err)
+	}
+
+	highest, err := l.HighestOffset()
+	assertNoError(t, err)
+
+	assertNoError(t, l.Truncate(highest))
+
+	// HighestOffset/LowestOffset must not panic now that every segment
+	// was removed; they used to index into an empty l.segments.
+	_, err = l.HighestOffset()
+	assertNoError(t, err)
+	_, err = l.LowestOffset()
+	assertNoError(t, err)
+
+	off, err := l.Append(context.Background(), *record)
+	assertNoError(t, err)
+	assertEqual(t, off, highest+1)
+}
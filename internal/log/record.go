@@ -0,0 +1,23 @@
+package log
+
+import api "github.com/drakelthedragon/proglog/api/v1"
+
+// Record is the unit of data appended to and read from the log. Offset is
+// set by the log itself when the record is appended; callers don't need to
+// (and shouldn't) set it.
+type Record struct {
+	Value  []byte `json:"value"`
+	Offset uint64 `json:"offset"`
+}
+
+// toWire converts a Record to the api/v1 wire message, giving the store's
+// Codec a single message shape to marshal regardless of whether it's
+// JSON or protobuf.
+func (r Record) toWire() *api.Record {
+	return &api.Record{Value: r.Value, Offset: r.Offset}
+}
+
+// recordFromWire converts an api/v1 wire message back to a Record.
+func recordFromWire(pb *api.Record) Record {
+	return Record{Value: pb.Value, Offset: pb.Offset}
+}
@@ -0,0 +1,154 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+
+	api "github.com/drakelthedragon/proglog/api/v1"
+)
+
+// segment ties a store and an index together: writes go to the store and
+// get an entry in the index so later reads can jump straight to the
+// record's position instead of scanning the store from the front.
+type segment struct {
+	store      *store
+	index      *index
+	baseOffset uint64
+	nextOffset uint64
+	config     Config
+	codec      Codec
+}
+
+// newSegment creates the store and index files for baseOffset in dir (or
+// opens them if they already exist from a previous run) and works out the
+// offset the segment should append its next record at. codec is the one
+// the enclosing Log was configured with, and the store stamps (or checks)
+// it so a segment can't be reopened under a different codec by mistake.
+func newSegment(dir string, baseOffset uint64, c Config, codec Codec) (*segment, error) {
+	s := &segment{
+		baseOffset: baseOffset,
+		config:     c,
+		codec:      codec,
+	}
+
+	storeFile, err := os.OpenFile(
+		path.Join(dir, fmt.Sprintf("%d%s", baseOffset, ".store")),
+		os.O_RDWR|os.O_CREATE|os.O_APPEND,
+		0644,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if s.store, err = newStore(storeFile, codec); err != nil {
+		return nil, err
+	}
+
+	indexFile, err := os.OpenFile(
+		path.Join(dir, fmt.Sprintf("%d%s", baseOffset, ".index")),
+		os.O_RDWR|os.O_CREATE,
+		0644,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if s.index, err = newIndex(indexFile, c.Segment.MaxIndexBytes); err != nil {
+		return nil, err
+	}
+
+	if off, _, err := s.index.Read(-1); err != nil {
+		// The index is empty, so the next record appended is the first in
+		// the segment.
+		s.nextOffset = baseOffset
+	} else {
+		s.nextOffset = baseOffset + uint64(off) + 1
+	}
+
+	return s, nil
+}
+
+// Append writes record to the segment's store, indexes its position, and
+// returns the offset it was stored under. ctx is forwarded to the store so
+// a canceled caller aborts before either the store write or the index
+// write happens.
+func (s *segment) Append(ctx context.Context, record Record) (offset uint64, err error) {
+	cur := s.nextOffset
+	record.Offset = cur
+
+	p, err := s.codec.Marshal(record.toWire())
+	if err != nil {
+		return 0, err
+	}
+
+	_, pos, err := s.store.Append(ctx, p)
+	if err != nil {
+		return 0, err
+	}
+
+	// Index entries are relative to the segment's base offset so they fit
+	// in the fixed 4-byte offset column no matter how far into the log the
+	// segment sits.
+	if err = s.index.Write(uint32(s.nextOffset-s.baseOffset), pos); err != nil {
+		return 0, err
+	}
+
+	s.nextOffset++
+
+	return cur, nil
+}
+
+// Read returns the record stored at the given offset. ctx is forwarded to
+// the store so a canceled caller aborts before the read happens.
+func (s *segment) Read(ctx context.Context, off uint64) (*Record, error) {
+	_, pos, err := s.index.Read(int64(off - s.baseOffset))
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := s.store.Read(ctx, pos)
+	if err != nil {
+		return nil, err
+	}
+
+	pb := &api.Record{}
+	if err := s.codec.Unmarshal(p, pb); err != nil {
+		return nil, err
+	}
+
+	record := recordFromWire(pb)
+	return &record, nil
+}
+
+// IsMaxed reports whether the segment has reached its configured store or
+// index size, meaning the log should seal it and open a new active
+// segment.
+func (s *segment) IsMaxed() bool {
+	return s.store.size >= s.config.Segment.MaxStoreBytes ||
+		s.index.size >= s.config.Segment.MaxIndexBytes
+}
+
+// Remove closes the segment and deletes its store and index files.
+func (s *segment) Remove() error {
+	if err := s.Close(); err != nil {
+		return err
+	}
+	if err := os.Remove(s.store.Name()); err != nil {
+		return err
+	}
+	if err := os.Remove(s.index.Name()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Close closes the segment's store and index files.
+func (s *segment) Close() error {
+	if err := s.index.Close(); err != nil {
+		return err
+	}
+	if err := s.store.Close(); err != nil {
+		return err
+	}
+	return nil
+}
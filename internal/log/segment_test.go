@@ -0,0 +1,63 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestSegment(t *testing.T) {
+	dir, err := ioutil.TempDir("", "segment_test")
+	assertNoError(t, err)
+	defer os.RemoveAll(dir)
+
+	c := Config{}
+	c.Segment.MaxStoreBytes = 1024
+	c.Segment.MaxIndexBytes = uint64(entWidth * 3)
+
+	s, err := newSegment(dir, 16, c, JSONCodec{})
+	assertNoError(t, err)
+	assertEqual(t, s.nextOffset, uint64(16))
+	if s.IsMaxed() {
+		t.Fatal("new segment should not be maxed")
+	}
+
+	// Each record gets a distinct value so a wrong Read position shows up
+	// as a mismatched value instead of being masked by every record
+	// looking the same.
+	for i := uint64(0); i < 3; i++ {
+		want := &Record{Value: []byte(fmt.Sprintf("record-%d", i))}
+
+		off, err := s.Append(context.Background(), *want)
+		assertNoError(t, err)
+		assertEqual(t, off, 16+i)
+
+		got, err := s.Read(context.Background(), off)
+		assertNoError(t, err)
+		assertEqual(t, string(got.Value), string(want.Value))
+	}
+
+	// The index is maxed out after 3 entries, so the segment is maxed even
+	// though the store isn't.
+	if !s.IsMaxed() {
+		t.Fatal("segment should be maxed once the index is full")
+	}
+
+	assertNoError(t, s.Close())
+
+	// Reopening the segment from disk should resume at the offset after
+	// the last entry we wrote.
+	s, err = newSegment(dir, 16, c, JSONCodec{})
+	assertNoError(t, err)
+	assertEqual(t, s.nextOffset, uint64(19))
+	if !s.IsMaxed() {
+		t.Fatal("reopened segment should still be maxed")
+	}
+
+	assertNoError(t, s.Remove())
+	if _, err := os.Stat(s.store.Name()); !os.IsNotExist(err) {
+		t.Fatal("store file should have been removed")
+	}
+}
@@ -2,7 +2,10 @@ package log
 
 import (
 	"bufio"
+	"bytes"
+	"context"
 	"encoding/binary"
+	"fmt"
 	"os"
 	"sync"
 )
@@ -32,6 +35,15 @@ const (
 	lenWidth = 8
 )
 
+// storeMagic marks a file as a proglog store, and storeHeaderLen is the
+// magic plus the one-byte codec ID that follows it. The header is written
+// once, when the store is created, so a store opened with a different
+// Codec than the one it was created with fails fast instead of
+// Unmarshal-ing garbage.
+var storeMagic = [4]byte{'P', 'L', 'O', 'G'}
+
+const storeHeaderLen = len(storeMagic) + 1
+
 // store is a simple wrapper around a file with two APIs to append
 // and read bytes to and from the file.
 type store struct {
@@ -41,8 +53,9 @@ type store struct {
 	size uint64
 }
 
-// newStore creates a store for the given file.
-func newStore(f *os.File) (*store, error) {
+// newStore creates a store for the given file, stamping it with codec's ID
+// if it's new or checking that stamp against codec if it already exists.
+func newStore(f *os.File, codec Codec) (*store, error) {
 	// Getting the file's information.
 	fi, err := os.Stat(f.Name())
 	if err != nil {
@@ -54,15 +67,69 @@ func newStore(f *os.File) (*store, error) {
 	// our service had restarted.
 	size := uint64(fi.Size())
 
-	return &store{
+	s := &store{
 		File: f,
 		size: size,
 		buf:  bufio.NewWriter(f),
-	}, nil
+	}
+
+	if size == 0 {
+		if err := s.writeHeader(codec); err != nil {
+			return nil, err
+		}
+	} else if err := s.checkHeader(codec); err != nil {
+		return nil, err
+	}
+
+	return s, nil
 }
 
-// Append persists the given bytes to the store.
-func (s *store) Append(p []byte) (n uint64, pos uint64, err error) {
+// writeHeader stamps a freshly created store with the magic bytes and
+// codec's ID, and accounts for the header in size so the first record's
+// position starts right after it.
+func (s *store) writeHeader(codec Codec) error {
+	var hdr [storeHeaderLen]byte
+	copy(hdr[:len(storeMagic)], storeMagic[:])
+	hdr[len(storeMagic)] = codec.ID()
+
+	if _, err := s.buf.Write(hdr[:]); err != nil {
+		return err
+	}
+	if err := s.buf.Flush(); err != nil {
+		return err
+	}
+	s.size = uint64(storeHeaderLen)
+
+	return nil
+}
+
+// checkHeader reads back the header of an existing store file and confirms
+// it was created with codec, returning an error that names the mismatch
+// rather than letting a wrong-codec Read return garbage.
+func (s *store) checkHeader(codec Codec) error {
+	var hdr [storeHeaderLen]byte
+	if _, err := s.File.ReadAt(hdr[:], 0); err != nil {
+		return err
+	}
+
+	if !bytes.Equal(hdr[:len(storeMagic)], storeMagic[:]) {
+		return fmt.Errorf("store: %s is not a proglog store file", s.File.Name())
+	}
+	if gotID := hdr[len(storeMagic)]; gotID != codec.ID() {
+		return fmt.Errorf("store: %s was created with codec %d, got codec %d", s.File.Name(), gotID, codec.ID())
+	}
+
+	return nil
+}
+
+// Append persists the given bytes to the store. ctx is checked before the
+// write starts so a caller that has already given up (e.g. a canceled
+// request) doesn't pay for a write nobody will read the result of.
+func (s *store) Append(ctx context.Context, p []byte) (n uint64, pos uint64, err error) {
+	if err := ctx.Err(); err != nil {
+		return 0, 0, err
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -97,7 +164,13 @@ func (s *store) Append(p []byte) (n uint64, pos uint64, err error) {
 
 	// Updating the store's size to the new number of total bytes written (now represents
 	// the start position of the next record).
-	s.size = n
+	//
+	// This must be += and not =: size is the cumulative end-of-file
+	// position, not this record's frame length. Setting it to n instead of
+	// adding shipped in chunk0-1 and silently corrupted pos for every
+	// record from the 3rd one in a segment onward; see
+	// TestStoreAppendPositionsAreCumulative.
+	s.size += n
 
 	// Return the total number of bytes written (Go APIs convention) and the position
 	// where the store holds the record in its file. The segment will use this
@@ -105,8 +178,14 @@ func (s *store) Append(p []byte) (n uint64, pos uint64, err error) {
 	return n, pos, nil
 }
 
-// Read returns the record stored at the given position.
-func (s *store) Read(pos uint64) ([]byte, error) {
+// Read returns the record stored at the given position. ctx is checked
+// before the read starts so a long-canceled caller doesn't block on a read
+// whose result it no longer wants.
+func (s *store) Read(ctx context.Context, pos uint64) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -173,6 +252,9 @@ func (s *store) Close() error {
 	if err := s.buf.Flush(); err != nil {
 		return err
 	}
+	if err := s.File.Sync(); err != nil {
+		return err
+	}
 
 	return s.File.Close()
 }
@@ -1,6 +1,8 @@
 package log
 
 import (
+	"context"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"testing"
@@ -16,10 +18,57 @@ func TestStoreAppendRead(t *testing.T) {
 	assertNoError(t, err)
 	defer os.Remove(f.Name())
 
-	_, err = newStore(f)
+	_, err = newStore(f, JSONCodec{})
 	assertNoError(t, err)
 }
 
+// TestStoreAppendPositionsAreCumulative appends records with distinct
+// values and reads every one of them back, not just the first. Reusing
+// the same value on every append (as the log tests do) masks a
+// wrong Read position with a coincidentally-right value; distinct values
+// catch it. This guards against s.size being overwritten by each Append
+// instead of accumulated, which corrupted every record from the 3rd one
+// in a segment onward across chunk0-1 through chunk0-5.
+func TestStoreAppendPositionsAreCumulative(t *testing.T) {
+	f, err := ioutil.TempFile("", "store_cumulative_test")
+	assertNoError(t, err)
+	defer os.Remove(f.Name())
+
+	s, err := newStore(f, JSONCodec{})
+	assertNoError(t, err)
+
+	const n = 5
+	var positions []uint64
+	for i := 0; i < n; i++ {
+		_, pos, err := s.Append(context.Background(), []byte(fmt.Sprintf("record-%d", i)))
+		assertNoError(t, err)
+		positions = append(positions, pos)
+	}
+
+	for i, pos := range positions {
+		got, err := s.Read(context.Background(), pos)
+		assertNoError(t, err)
+		assertEqual(t, string(got), fmt.Sprintf("record-%d", i))
+	}
+}
+
+func TestStoreHeaderRejectsWrongCodec(t *testing.T) {
+	f, err := ioutil.TempFile("", "store_wrong_codec_test")
+	assertNoError(t, err)
+	defer os.Remove(f.Name())
+
+	_, err = newStore(f, JSONCodec{})
+	assertNoError(t, err)
+
+	reopened, err := os.OpenFile(f.Name(), os.O_RDWR, 0644)
+	assertNoError(t, err)
+	defer reopened.Close()
+
+	if _, err := newStore(reopened, ProtobufCodec{}); err == nil {
+		t.Fatal("expected an error reopening a JSON store with ProtobufCodec")
+	}
+}
+
 func assertEqual[T comparable](t testing.TB, got, want T) {
 	t.Helper()
 
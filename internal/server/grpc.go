@@ -0,0 +1,149 @@
+package server
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	api "github.com/drakelthedragon/proglog/api/v1"
+	"github.com/drakelthedragon/proglog/internal/log"
+)
+
+// consumeStreamPollInterval caps how long ConsumeStream waits between
+// retries once it's caught up to the end of the log, so an idle tailing
+// connection backs off instead of busy-spinning a CPU core.
+const consumeStreamPollInterval = 25 * time.Millisecond
+
+// defaultMaxRecvMsgSize caps an incoming RPC message when the caller
+// doesn't configure one of their own. This is grpc-go's own default,
+// made explicit so it's configurable instead of baked in.
+const defaultMaxRecvMsgSize = 1024 * 1024 * 4 // 4MB
+
+// Config holds the dependencies the gRPC server needs to serve the Log
+// service.
+type Config struct {
+	CommitLog *log.Log
+
+	// MaxRecvMsgSize caps the size of an incoming RPC message before
+	// grpc-go rejects it with codes.ResourceExhausted. Zero means
+	// defaultMaxRecvMsgSize.
+	MaxRecvMsgSize int
+}
+
+var _ api.LogServer = (*grpcServer)(nil)
+
+// grpcServer implements api.LogServer against a *log.Log.
+type grpcServer struct {
+	api.UnimplementedLogServer
+	*Config
+}
+
+func newGRPCServer(config *Config) (*grpcServer, error) {
+	return &grpcServer{Config: config}, nil
+}
+
+// NewGRPCServer creates a *grpc.Server, registers the Log service on it, and
+// returns it ready for the caller to Serve.
+func NewGRPCServer(config *Config) (*grpc.Server, error) {
+	maxRecvMsgSize := config.MaxRecvMsgSize
+	if maxRecvMsgSize == 0 {
+		maxRecvMsgSize = defaultMaxRecvMsgSize
+	}
+	gsrv := grpc.NewServer(grpc.MaxRecvMsgSize(maxRecvMsgSize))
+
+	srv, err := newGRPCServer(config)
+	if err != nil {
+		return nil, err
+	}
+	api.RegisterLogServer(gsrv, srv)
+
+	return gsrv, nil
+}
+
+// Produce appends the request's record to the log and reports its offset.
+func (s *grpcServer) Produce(ctx context.Context, req *api.ProduceRequest) (*api.ProduceResponse, error) {
+	if req.Record == nil {
+		return nil, status.Error(codes.InvalidArgument, "record is required")
+	}
+
+	off, err := s.CommitLog.Append(ctx, log.Record{Value: req.Record.Value})
+	if err != nil {
+		return nil, err
+	}
+	return &api.ProduceResponse{Offset: off}, nil
+}
+
+// Consume reads the record stored at the requested offset.
+func (s *grpcServer) Consume(ctx context.Context, req *api.ConsumeRequest) (*api.ConsumeResponse, error) {
+	record, err := s.CommitLog.Read(ctx, req.Offset)
+	if err != nil {
+		if err == log.ErrOffsetNotFound {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, err
+	}
+	return &api.ConsumeResponse{
+		Record: &api.Record{Value: record.Value, Offset: record.Offset},
+	}, nil
+}
+
+// ProduceStream lets a client batch-produce many records over one
+// connection: it reads a request, appends it, and echoes back the offset
+// for each message the client sends.
+func (s *grpcServer) ProduceStream(stream api.Log_ProduceStreamServer) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			// The client called CloseSend(); that's a clean end of the
+			// stream, not an error.
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		res, err := s.Produce(stream.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		if err = stream.Send(res); err != nil {
+			return err
+		}
+	}
+}
+
+// ConsumeStream lets a client tail the log starting at the requested
+// offset: once it catches up to the end of the log it waits for new
+// records to be appended instead of returning an error.
+func (s *grpcServer) ConsumeStream(req *api.ConsumeRequest, stream api.Log_ConsumeStreamServer) error {
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		default:
+			res, err := s.Consume(stream.Context(), req)
+			switch status.Code(err) {
+			case codes.OK:
+			case codes.NotFound:
+				select {
+				case <-stream.Context().Done():
+					return nil
+				case <-time.After(consumeStreamPollInterval):
+				}
+				continue
+			default:
+				return err
+			}
+
+			if err = stream.Send(res); err != nil {
+				return err
+			}
+			req.Offset++
+		}
+	}
+}
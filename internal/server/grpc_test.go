@@ -0,0 +1,176 @@
+package server
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	api "github.com/drakelthedragon/proglog/api/v1"
+	"github.com/drakelthedragon/proglog/internal/log"
+)
+
+// bufconnListenSize is arbitrary; it only needs to be large enough for
+// the handful of RPCs each test below makes.
+const bufconnListenSize = 1024 * 1024
+
+// newTestServer spins up a *grpc.Server backed by a fresh on-disk log,
+// serves it over an in-memory bufconn listener, and returns a client
+// dialed against it along with a func to tear everything down.
+func newTestServer(t *testing.T) (api.LogClient, func()) {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "grpc_server_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commitLog, err := log.NewLog(dir, log.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv, err := NewGRPCServer(&Config{CommitLog: commitLog})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lis := bufconn.Listen(bufconnListenSize)
+	go srv.Serve(lis)
+
+	cc, err := grpc.Dial(
+		"bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := api.NewLogClient(cc)
+
+	return client, func() {
+		cc.Close()
+		srv.Stop()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestGRPCProduceConsume(t *testing.T) {
+	client, teardown := newTestServer(t)
+	defer teardown()
+
+	ctx := context.Background()
+	want := &api.Record{Value: []byte("hello world")}
+
+	produce, err := client.Produce(ctx, &api.ProduceRequest{Record: want})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	consume, err := client.Consume(ctx, &api.ConsumeRequest{Offset: produce.Offset})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(consume.Record.Value) != string(want.Value) {
+		t.Fatalf("got record %q, want %q", consume.Record.Value, want.Value)
+	}
+	if consume.Record.Offset != produce.Offset {
+		t.Fatalf("got offset %d, want %d", consume.Record.Offset, produce.Offset)
+	}
+}
+
+func TestGRPCProduceNilRecord(t *testing.T) {
+	client, teardown := newTestServer(t)
+	defer teardown()
+
+	_, err := client.Produce(context.Background(), &api.ProduceRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("got status %v, want codes.InvalidArgument", status.Code(err))
+	}
+}
+
+func TestGRPCConsumeOffsetNotFound(t *testing.T) {
+	client, teardown := newTestServer(t)
+	defer teardown()
+
+	_, err := client.Consume(context.Background(), &api.ConsumeRequest{Offset: 1})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("got status %v, want codes.NotFound", status.Code(err))
+	}
+}
+
+func TestGRPCProduceStream(t *testing.T) {
+	client, teardown := newTestServer(t)
+	defer teardown()
+
+	stream, err := client.ProduceStream(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	records := []string{"first", "second", "third"}
+	for i, value := range records {
+		if err := stream.Send(&api.ProduceRequest{Record: &api.Record{Value: []byte(value)}}); err != nil {
+			t.Fatal(err)
+		}
+
+		res, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Offset != uint64(i) {
+			t.Fatalf("got offset %d, want %d", res.Offset, i)
+		}
+	}
+
+	if err := stream.CloseSend(); err != nil {
+		t.Fatal(err)
+	}
+	// CloseSend ends the stream cleanly: the server should stop with
+	// io.EOF, not surface it as an RPC error.
+	if _, err := stream.Recv(); err != io.EOF {
+		t.Fatalf("got %v, want io.EOF", err)
+	}
+}
+
+func TestGRPCConsumeStream(t *testing.T) {
+	client, teardown := newTestServer(t)
+	defer teardown()
+
+	ctx := context.Background()
+	want := &api.Record{Value: []byte("hello world")}
+	if _, err := client.Produce(ctx, &api.ProduceRequest{Record: want}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := client.ConsumeStream(ctx, &api.ConsumeRequest{Offset: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(res.Record.Value) != string(want.Value) {
+		t.Fatalf("got record %q, want %q", res.Record.Value, want.Value)
+	}
+
+	// Cancelling the client context stops the server from blocking
+	// forever waiting for a record past the end of the log.
+	cancel()
+}